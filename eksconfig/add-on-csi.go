@@ -0,0 +1,58 @@
+package eksconfig
+
+const (
+	// ebsCSIDriverPolicyARN is the AWS managed policy granting the EBS
+	// CSI driver controller permission to create/attach/delete volumes.
+	// ref. https://docs.aws.amazon.com/eks/latest/userguide/csi-iam-role.html
+	ebsCSIDriverPolicyARN = "arn:aws:iam::aws:policy/service-role/AmazonEBSCSIDriverPolicy"
+	// efsCSIDriverPolicyARN is the AWS managed policy granting the EFS
+	// CSI driver controller permission to manage access points.
+	// ref. https://docs.aws.amazon.com/eks/latest/userguide/efs-csi.html
+	efsCSIDriverPolicyARN = "arn:aws:iam::aws:policy/service-role/AmazonEFSCSIDriverPolicy"
+)
+
+// efsUnsupportedRegions lists regions without an EFS endpoint, where
+// "AddOnCSI.EnableEFS" must be rejected rather than failing later at
+// CFN create time.
+// ref. https://docs.aws.amazon.com/general/latest/gr/efs.html
+var efsUnsupportedRegions = map[string]struct{}{
+	"ap-east-1":  {},
+	"me-south-1": {},
+	"af-south-1": {},
+}
+
+// AddOnCSI defines parameters for EKS cluster add-on that installs the
+// AWS EBS CSI driver and, optionally, provisions an EFS file system
+// plus the EFS CSI driver for dynamic volume provisioning. The IAM
+// policies the CSI controllers need are wired through "AddOnIRSA".
+type AddOnCSI struct {
+	Enable bool `json:"enable"`
+
+	Namespace string `json:"namespace,omitempty"`
+
+	EBSDriverRoleServiceAccount string `json:"ebs-driver-role-service-account,omitempty"`
+
+	// EnableEFS provisions an EFS file system and the EFS CSI driver,
+	// in addition to the always-installed EBS CSI driver.
+	EnableEFS                   bool   `json:"enable-efs"`
+	EFSDriverRoleServiceAccount string `json:"efs-driver-role-service-account,omitempty"`
+	EFSFileSystemID             string `json:"efs-file-system-id,omitempty"`
+
+	CSIStress *AddOnCSIStress `json:"csi-stress,omitempty"`
+}
+
+// AddOnCSIStress defines a workload that creates N StatefulSets, each
+// with a dynamically-provisioned PVC of "VolumeSize" GiB, and
+// writes/reads data to measure dynamic-provisioning latency.
+type AddOnCSIStress struct {
+	Enable bool `json:"enable"`
+
+	Namespace string `json:"namespace,omitempty"`
+
+	StatefulSets int `json:"stateful-sets,omitempty"`
+	VolumeSize   int `json:"volume-size,omitempty"`
+
+	// StressResultPath is the CSV output path, written in the same
+	// style as "AddOnSecrets.WritesResultPath".
+	StressResultPath string `json:"stress-result-path,omitempty"`
+}
@@ -0,0 +1,52 @@
+package eksconfig
+
+// AddOnIRSA defines parameters for EKS cluster
+// add-on "IAM Roles for Service Accounts" (IRSA).
+// ref. https://docs.aws.amazon.com/eks/latest/userguide/iam-roles-for-service-accounts.html
+type AddOnIRSA struct {
+	Enable bool `json:"enable"`
+
+	Namespace          string `json:"namespace,omitempty"`
+	ServiceAccountName string `json:"service-account-name,omitempty"`
+
+	RoleName              string   `json:"role-name,omitempty"`
+	RoleManagedPolicyARNs []string `json:"role-managed-policy-arns,omitempty"`
+
+	ConfigMapName           string `json:"config-map-name,omitempty"`
+	ConfigMapScriptFileName string `json:"config-map-script-file-name,omitempty"`
+
+	S3BucketName string `json:"s3-bucket-name,omitempty"`
+	S3Key        string `json:"s3-key,omitempty"`
+
+	DeploymentName       string `json:"deployment-name,omitempty"`
+	DeploymentReplicas   int    `json:"deployment-replicas,omitempty"`
+	DeploymentResultPath string `json:"deployment-result-path,omitempty"`
+
+	// ServiceAccountRoles maps "<namespace>/<service-account-name>" to the
+	// IAM role to provision for it, so a single cluster can federate many
+	// service accounts rather than just the one named by "ServiceAccountName".
+	ServiceAccountRoles map[string]ServiceAccountRole `json:"service-account-roles,omitempty"`
+
+	// PublishDiscovery uploads the cluster's OIDC discovery document
+	// ("/.well-known/openid-configuration") and JWKS to a public S3
+	// bucket, so external AWS accounts can federate against this
+	// cluster's OIDC issuer without going through the EKS-managed one.
+	PublishDiscovery      bool   `json:"publish-discovery"`
+	DiscoveryS3BucketName string `json:"discovery-s3-bucket-name,omitempty"`
+}
+
+// ServiceAccountRole defines the IAM role to create for a single
+// "<namespace>/<service-account-name>" pair under "AddOnIRSA".
+type ServiceAccountRole struct {
+	// RoleName is the auto-generated or user-supplied IAM role name.
+	RoleName string `json:"role-name,omitempty"`
+
+	ManagedPolicyARNs []string `json:"managed-policy-arns,omitempty"`
+	// InlinePolicy is an optional raw IAM policy document JSON string.
+	InlinePolicy string `json:"inline-policy,omitempty"`
+
+	// Wildcard federates every service account in the namespace, via a
+	// trust policy "sub" condition of "system:serviceaccount:<ns>:*",
+	// instead of a single "system:serviceaccount:<ns>:<name>".
+	Wildcard bool `json:"wildcard"`
+}
@@ -0,0 +1,45 @@
+package eksconfig
+
+// AddOnManagedNodeGroups defines "Managed Node Group" configuration.
+// ref. https://docs.aws.amazon.com/eks/latest/userguide/managed-node-groups.html
+type AddOnManagedNodeGroups struct {
+	Enable      bool   `json:"enable"`
+	SigningName string `json:"signing-name,omitempty"`
+
+	RoleName              string   `json:"role-name,omitempty"`
+	RoleServicePrincipals []string `json:"role-service-principals,omitempty"`
+	RoleManagedPolicyARNs []string `json:"role-managed-policy-arns,omitempty"`
+
+	SSHKeyPairName             string `json:"ssh-key-pair-name,omitempty"`
+	RemoteAccessPrivateKeyPath string `json:"remote-access-private-key-path,omitempty"`
+	RemoteAccessUserName       string `json:"remote-access-user-name,omitempty"`
+
+	LogsDir string `json:"logs-dir,omitempty"`
+
+	MNGs map[string]MNG `json:"mngs,omitempty"`
+}
+
+// MNG defines a single "Managed Node Group" configuration.
+type MNG struct {
+	Name           string `json:"name,omitempty"`
+	ReleaseVersion string `json:"release-version,omitempty"`
+
+	AMIType string `json:"ami-type,omitempty"`
+	// AMIFamily selects the OS family ("AL2", "Bottlerocket", "Ubuntu2004",
+	// "Windows2019") used to derive family-specific user-data, remote
+	// access user name, and the SSM AMI parameter path; if empty, it's
+	// defaulted to the family of "AMIType", and a mismatch is rejected.
+	AMIFamily AMIFamily `json:"ami-family,omitempty"`
+
+	ASGMinSize         int `json:"asg-min-size,omitempty"`
+	ASGMaxSize         int `json:"asg-max-size,omitempty"`
+	ASGDesiredCapacity int `json:"asg-desired-capacity,omitempty"`
+
+	InstanceTypes []string `json:"instance-types,omitempty"`
+	VolumeSize    int      `json:"volume-size,omitempty"`
+
+	// RemoteAccessUserName overrides "AddOnManagedNodeGroups.RemoteAccessUserName"
+	// for this node group; if empty, it's defaulted from "AMIFamily"
+	// ("ec2-user", "ubuntu", "Administrator", etc.).
+	RemoteAccessUserName string `json:"remote-access-user-name,omitempty"`
+}
@@ -0,0 +1,78 @@
+package eksconfig
+
+// AddOnNodeGroups defines self-managed EC2 Auto Scaling Group node
+// groups, provisioned via CloudFormation (launch templates + ASG +
+// bootstrap user-data) as an alternative to "AddOnManagedNodeGroups".
+// Self-managed groups support mixed-instance policies, spot pools,
+// custom kubelet flags, and taints/labels set at boot time, none of
+// which the EKS managed node group API exposes.
+type AddOnNodeGroups struct {
+	Enable      bool   `json:"enable"`
+	SigningName string `json:"signing-name,omitempty"`
+
+	RoleName              string   `json:"role-name,omitempty"`
+	RoleServicePrincipals []string `json:"role-service-principals,omitempty"`
+	RoleManagedPolicyARNs []string `json:"role-managed-policy-arns,omitempty"`
+
+	SSHKeyPairName             string `json:"ssh-key-pair-name,omitempty"`
+	RemoteAccessPrivateKeyPath string `json:"remote-access-private-key-path,omitempty"`
+
+	LogsDir string `json:"logs-dir,omitempty"`
+
+	ASGs map[string]ASG `json:"asgs,omitempty"`
+}
+
+// ASG defines a single self-managed Auto Scaling Group node group.
+type ASG struct {
+	Name string `json:"name,omitempty"`
+
+	AMIType   string    `json:"ami-type,omitempty"`
+	AMIFamily AMIFamily `json:"ami-family,omitempty"`
+
+	// ImageID is the AMI ID launched by this node group's launch
+	// template; if empty, "ValidateAndSetDefaults" defaults
+	// "AMISSMParameterPath" from "AMIFamily" and the cluster-creation
+	// runner is expected to resolve "ImageID" from that SSM parameter
+	// right before creating the launch template.
+	ImageID string `json:"image-id,omitempty"`
+	// AMISSMParameterPath is the public SSM parameter path used to
+	// resolve the latest "ImageID" for "AMIFamily"; when "ImageID" is
+	// empty, "ValidateAndSetDefaults" defaults it to the family's path
+	// prefix only (e.g. "/aws/service/eks/optimized-ami"), without the
+	// Kubernetes version or image suffix.
+	AMISSMParameterPath string `json:"ami-ssm-parameter-path,omitempty"`
+
+	ASGMinSize         int `json:"asg-min-size,omitempty"`
+	ASGMaxSize         int `json:"asg-max-size,omitempty"`
+	ASGDesiredCapacity int `json:"asg-desired-capacity,omitempty"`
+
+	InstanceTypes []string `json:"instance-types,omitempty"`
+	VolumeSize    int      `json:"volume-size,omitempty"`
+
+	// MixedInstancePolicy, when non-nil, launches across multiple
+	// instance types/purchase options instead of a single InstanceTypes[0].
+	MixedInstancePolicy *MixedInstancePolicy `json:"mixed-instance-policy,omitempty"`
+	// SpotInstancePools is the number of spot pools used when
+	// "MixedInstancePolicy.SpotAllocationStrategy" is "capacity-optimized".
+	SpotInstancePools int `json:"spot-instance-pools,omitempty"`
+
+	KubeletExtraArgs string `json:"kubelet-extra-args,omitempty"`
+
+	Taints []Taint           `json:"taints,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// MixedInstancePolicy defines on-demand/spot instance mix for a self-managed ASG.
+type MixedInstancePolicy struct {
+	InstanceTypes                       []string `json:"instance-types,omitempty"`
+	OnDemandBaseCapacity                int      `json:"on-demand-base-capacity,omitempty"`
+	OnDemandPercentageAboveBaseCapacity int      `json:"on-demand-percentage-above-base-capacity,omitempty"`
+	SpotAllocationStrategy              string   `json:"spot-allocation-strategy,omitempty"`
+}
+
+// Taint defines a Kubernetes node taint applied at boot time via user-data.
+type Taint struct {
+	Key    string `json:"key,omitempty"`
+	Value  string `json:"value,omitempty"`
+	Effect string `json:"effect,omitempty"`
+}
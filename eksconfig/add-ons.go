@@ -0,0 +1,56 @@
+package eksconfig
+
+// AddOnNLBHelloWorld defines parameters for EKS cluster
+// add-on "Hello World" application with NLB.
+type AddOnNLBHelloWorld struct {
+	Enable             bool   `json:"enable"`
+	Namespace          string `json:"namespace,omitempty"`
+	DeploymentReplicas int32  `json:"deployment-replicas,omitempty"`
+}
+
+// AddOnALB2048 defines parameters for EKS cluster
+// add-on "2048" application with ALB Ingress Controller.
+type AddOnALB2048 struct {
+	Enable                 bool   `json:"enable"`
+	Namespace              string `json:"namespace,omitempty"`
+	PolicyName             string `json:"policy-name,omitempty"`
+	DeploymentReplicasALB  int32  `json:"deployment-replicas-alb,omitempty"`
+	DeploymentReplicas2048 int32  `json:"deployment-replicas-2048,omitempty"`
+}
+
+// AddOnJobPerl defines parameters for EKS cluster
+// add-on Job "perl" (computes pi).
+type AddOnJobPerl struct {
+	Enable    bool   `json:"enable"`
+	Namespace string `json:"namespace,omitempty"`
+	Completes int    `json:"completes,omitempty"`
+	Parallels int    `json:"parallels,omitempty"`
+}
+
+// AddOnJobEcho defines parameters for EKS cluster
+// add-on Job "echo" (writes data to etcd via Pod/Secret objects).
+type AddOnJobEcho struct {
+	Enable    bool   `json:"enable"`
+	Namespace string `json:"namespace,omitempty"`
+	Completes int    `json:"completes,omitempty"`
+	Parallels int    `json:"parallels,omitempty"`
+	Size      int    `json:"size,omitempty"`
+}
+
+// AddOnSecrets defines parameters for EKS cluster
+// add-on "Secrets" (writes/reads many "Secret" objects to stress etcd).
+type AddOnSecrets struct {
+	Enable    bool   `json:"enable"`
+	Namespace string `json:"namespace,omitempty"`
+
+	Objects int `json:"objects,omitempty"`
+	Size    int `json:"size,omitempty"`
+
+	SecretQPS   int `json:"secret-qps,omitempty"`
+	SecretBurst int `json:"secret-burst,omitempty"`
+	PodQPS      int `json:"pod-qps,omitempty"`
+	PodBurst    int `json:"pod-burst,omitempty"`
+
+	WritesResultPath string `json:"writes-result-path,omitempty"`
+	ReadsResultPath  string `json:"reads-result-path,omitempty"`
+}
@@ -0,0 +1,153 @@
+package eksconfig
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AMIFamily identifies the OS family of a managed node group's AMI,
+// beyond the plain "AMIType" the EKS API accepts, so the tester can
+// pick family-specific user-data, SSH user, and SSM-agent bootstrap.
+type AMIFamily string
+
+const (
+	// AMIFamilyAL2 is Amazon Linux 2 (the existing "AL2_x86_64"/"AL2_x86_64_GPU" default).
+	AMIFamilyAL2 AMIFamily = "AL2"
+	// AMIFamilyBottlerocket is Bottlerocket OS.
+	AMIFamilyBottlerocket AMIFamily = "Bottlerocket"
+	// AMIFamilyUbuntu is Ubuntu 20.04.
+	AMIFamilyUbuntu AMIFamily = "Ubuntu2004"
+	// AMIFamilyWindows is Windows Server 2019.
+	AMIFamilyWindows AMIFamily = "Windows2019"
+)
+
+// amiFamilyDefaults describes the family-specific defaults needed to
+// bootstrap a managed node group: the remote access (SSH/RDP) user, and
+// the public SSM parameter path used to resolve the latest AMI ID for
+// the family.
+//
+// For "AddOnManagedNodeGroups", "ssmParameterPrefix" is unused: the EKS
+// "CreateNodegroup" API resolves the latest AMI for a given "AMIType"
+// itself, so "NewDefault" only ever needs to hardcode the AMIType enum
+// ("AL2_x86_64"), never an AMI ID. It's only "AddOnNodeGroups"
+// (self-managed ASGs) that launches instances from an AMI ID the
+// tester must resolve itself, via "ASG.AMISSMParameterPath"
+// below — see the defaulting in "ValidateAndSetDefaults".
+type amiFamilyDefaults struct {
+	remoteAccessUserName string
+	ssmParameterPrefix   string
+}
+
+// amiFamilyRegistry maps each supported "AMIFamily" to its defaults.
+var amiFamilyRegistry = map[AMIFamily]amiFamilyDefaults{
+	AMIFamilyAL2: {
+		remoteAccessUserName: "ec2-user",
+		ssmParameterPrefix:   "/aws/service/eks/optimized-ami",
+	},
+	AMIFamilyBottlerocket: {
+		remoteAccessUserName: "ec2-user",
+		ssmParameterPrefix:   "/aws/service/bottlerocket/aws-k8s",
+	},
+	AMIFamilyUbuntu: {
+		remoteAccessUserName: "ubuntu",
+		ssmParameterPrefix:   "/aws/service/canonical/ubuntu/eks",
+	},
+	AMIFamilyWindows: {
+		remoteAccessUserName: "Administrator",
+		ssmParameterPrefix:   "/aws/service/ami-windows-latest",
+	},
+}
+
+// amiTypeToFamily maps every "AMIType" string the real EKS
+// "CreateNodegroup" API accepts to its "AMIFamily", so the two fields
+// can't silently disagree on what's actually being launched. There is
+// no EKS-managed "AMIType" for Ubuntu — Ubuntu node groups are only
+// supported through "AddOnNodeGroups" (self-managed ASGs), where
+// "AMIType" is internal bookkeeping rather than a real API enum, so
+// "AMIFamilyUbuntu" is deliberately absent from this map.
+var amiTypeToFamily = map[string]AMIFamily{
+	"AL2_x86_64":               AMIFamilyAL2,
+	"AL2_x86_64_GPU":           AMIFamilyAL2,
+	"AL2_ARM_64":               AMIFamilyAL2,
+	"BOTTLEROCKET_x86_64":      AMIFamilyBottlerocket,
+	"BOTTLEROCKET_ARM_64":      AMIFamilyBottlerocket,
+	"WINDOWS_FULL_2019_x86_64": AMIFamilyWindows,
+	"WINDOWS_CORE_2019_x86_64": AMIFamilyWindows,
+}
+
+// amiTypeToFamilySelfManaged extends "amiTypeToFamily" with the
+// "AMIType" strings only meaningful to "AddOnNodeGroups" (self-managed
+// ASGs), where there's no EKS API to reject them and "AMIType" is just
+// metadata this tester uses to pick user-data/bootstrap behavior.
+var amiTypeToFamilySelfManaged = map[string]AMIFamily{
+	"UBUNTU2004_x86_64": AMIFamilyUbuntu,
+	"UBUNTU2004_ARM_64": AMIFamilyUbuntu,
+}
+
+// amiFamilyForAMIType returns the "AMIFamily" a given "AMIType" string
+// belongs to, or an error if the AMIType is unrecognized. Use this for
+// "AddOnManagedNodeGroups", where "AMIType" is sent verbatim to the EKS
+// "CreateNodegroup" API and must be one of its real enum values.
+func amiFamilyForAMIType(amiType string) (AMIFamily, error) {
+	family, ok := amiTypeToFamily[amiType]
+	if !ok {
+		return "", fmt.Errorf("unknown AMIType %q", amiType)
+	}
+	return family, nil
+}
+
+// amiFamilyForSelfManagedAMIType is "amiFamilyForAMIType" plus the
+// extra "AMIType" strings only valid for "AddOnNodeGroups" (self-managed
+// ASGs), which never reach the EKS API.
+func amiFamilyForSelfManagedAMIType(amiType string) (AMIFamily, error) {
+	if family, ok := amiTypeToFamily[amiType]; ok {
+		return family, nil
+	}
+	if family, ok := amiTypeToFamilySelfManaged[amiType]; ok {
+		return family, nil
+	}
+	return "", fmt.Errorf("unknown AMIType %q", amiType)
+}
+
+// ssmParameterPathForFamily returns the public SSM parameter path used
+// to resolve the latest AMI ID for "family" (e.g.
+// "/aws/service/eks/optimized-ami/1.18/amazon-linux-2/recommended/image_id"
+// once the cluster's Kubernetes version is appended by the caller), or
+// false if "family" has no registered path.
+func ssmParameterPathForFamily(family AMIFamily) (string, bool) {
+	def, ok := amiFamilyRegistry[family]
+	if !ok || def.ssmParameterPrefix == "" {
+		return "", false
+	}
+	return def.ssmParameterPrefix, true
+}
+
+// graviton-capable instance type prefixes, used to validate arm64 AMI types.
+var gravitonInstanceTypePrefixes = []string{"a1.", "c6g.", "m6g.", "r6g.", "t4g."}
+
+// isARM64AMIType reports whether an EKS "AMIType" string (e.g.
+// "AL2_ARM_64") targets arm64/Graviton nodes.
+func isARM64AMIType(amiType string) bool {
+	return strings.Contains(amiType, "ARM_64")
+}
+
+// validateAMITypeInstanceTypes enforces that arm64 AMI types are only
+// paired with Graviton instance types.
+func validateAMITypeInstanceTypes(amiType string, instanceTypes []string) error {
+	if !isARM64AMIType(amiType) {
+		return nil
+	}
+	for _, itp := range instanceTypes {
+		graviton := false
+		for _, px := range gravitonInstanceTypePrefixes {
+			if strings.HasPrefix(itp, px) {
+				graviton = true
+				break
+			}
+		}
+		if !graviton {
+			return fmt.Errorf("AMIType %q requires Graviton instance types, got %q", amiType, itp)
+		}
+	}
+	return nil
+}
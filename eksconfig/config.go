@@ -0,0 +1,153 @@
+package eksconfig
+
+import (
+	"encoding/json"
+	"io/ioutil"
+)
+
+// Config defines EKS (Elastic Kubernetes Service) cluster configuration.
+type Config struct {
+	ConfigPath                string `json:"config-path,omitempty"`
+	KubectlCommandsOutputPath string `json:"kubectl-commands-output-path,omitempty"`
+	SSHCommandsOutputPath     string `json:"ssh-commands-output-path,omitempty"`
+	KubeConfigPath            string `json:"kubeconfig-path,omitempty"`
+	Name                      string `json:"name,omitempty"`
+	AWSCLIPath                string `json:"aws-cli-path,omitempty"`
+
+	Region string `json:"region,omitempty"`
+
+	LogLevel   string   `json:"log-level,omitempty"`
+	LogOutputs []string `json:"log-outputs,omitempty"`
+
+	KubectlDownloadURL string `json:"kubectl-download-url,omitempty"`
+	KubectlPath        string `json:"kubectl-path,omitempty"`
+
+	OnFailureDelete            bool `json:"on-failure-delete"`
+	OnFailureDeleteWaitSeconds int  `json:"on-failure-delete-wait-seconds,omitempty"`
+
+	// EventLogPath is the local JSON-lines file that every phase
+	// transition (CFN stack create/delete, EKS cluster create, MNG
+	// ready, add-on install start/end, test run, teardown) is
+	// recorded to via "pkg/events".
+	EventLogPath string `json:"event-log-path,omitempty"`
+	// CloudWatchLogsGroupName streams the same events to a CloudWatch
+	// Logs group in addition to "EventLogPath"; "ValidateAndSetDefaults"
+	// defaults it to "Name" if left empty. The cluster-creation runner
+	// is expected to call "events.VerifyRoleCanPutLogEvents" against
+	// this group before handing events to "events.NewCloudWatchSink".
+	CloudWatchLogsGroupName string `json:"cloudwatch-logs-group-name,omitempty"`
+
+	Parameters *Parameters `json:"parameters,omitempty"`
+
+	AddOnManagedNodeGroups *AddOnManagedNodeGroups `json:"addon-managed-node-groups,omitempty"`
+	AddOnNodeGroups        *AddOnNodeGroups        `json:"addon-node-groups,omitempty"`
+	AddOnNLBHelloWorld     *AddOnNLBHelloWorld     `json:"addon-nlb-hello-world,omitempty"`
+	AddOnALB2048           *AddOnALB2048           `json:"addon-alb-2048,omitempty"`
+	AddOnJobPerl           *AddOnJobPerl           `json:"addon-job-perl,omitempty"`
+	AddOnJobEcho           *AddOnJobEcho           `json:"addon-job-echo,omitempty"`
+	AddOnSecrets           *AddOnSecrets           `json:"addon-secrets,omitempty"`
+	AddOnIRSA              *AddOnIRSA              `json:"addon-irsa,omitempty"`
+	AddOnCSI               *AddOnCSI               `json:"addon-csi,omitempty"`
+
+	Status                  *Status                  `json:"status,omitempty"`
+	StatusManagedNodeGroups *StatusManagedNodeGroups `json:"status-managed-node-groups,omitempty"`
+}
+
+// Parameters defines parameters for EKS cluster creation.
+type Parameters struct {
+	ClusterSigningName string `json:"cluster-signing-name,omitempty"`
+	Version            string `json:"version,omitempty"`
+
+	ClusterRoleServicePrincipals []string `json:"cluster-role-service-principals,omitempty"`
+	ClusterRoleManagedPolicyARNs []string `json:"cluster-role-managed-policy-arns,omitempty"`
+	ClusterRoleARN               string   `json:"cluster-role-arn,omitempty"`
+
+	VPCCIDR            string `json:"vpc-cidr,omitempty"`
+	PrivateSubnetCIDR1 string `json:"private-subnet-cidr-1,omitempty"`
+	PrivateSubnetCIDR2 string `json:"private-subnet-cidr-2,omitempty"`
+	PrivateSubnetCIDR3 string `json:"private-subnet-cidr-3,omitempty"`
+
+	PrivateSubnetIDs            []string `json:"private-subnet-ids,omitempty"`
+	PublicSubnetIDs             []string `json:"public-subnet-ids,omitempty"`
+	ControlPlaneSecurityGroupID string   `json:"control-plane-security-group-id,omitempty"`
+	NodeSecurityGroupID         string   `json:"node-security-group-id,omitempty"`
+	LBSecurityGroupID           string   `json:"lb-security-group-id,omitempty"`
+
+	// SecurityGroupOverrides lets a user plug into an existing VPC by
+	// supplying pre-created security group and subnet IDs, instead of
+	// always provisioning fresh CloudFormation stacks for them.
+	// When non-nil, "ValidateAndSetDefaults" copies the IDs verbatim onto
+	// "Parameters" and skips the "VPCCIDR"/"PrivateSubnetCIDR*" checks;
+	// it creates and tags no CFN stacks itself.
+	SecurityGroupOverrides *SecurityGroupOverrides `json:"security-group-overrides,omitempty"`
+}
+
+// SecurityGroupOverrides defines pre-existing, shared security group IDs
+// to reuse per role, and the existing subnets they apply to. When set,
+// "ValidateAndSetDefaults" copies these IDs onto "Parameters" verbatim
+// and skips the VPC CIDR checks.
+//
+// "ValidateAndSetDefaults" only checks that the IDs this package needs
+// are present; it does not verify the referenced groups already allow
+// the kubelet/NLB/ALB ingress these add-ons need, since doing so means
+// calling the live EC2 API, which this config-only package never does
+// (see the "validate shared SG/subnet overrides" comment in
+// validate-defaults.go).
+type SecurityGroupOverrides struct {
+	// ControlPlaneSecurityGroupID is the existing security group ID
+	// attached to the EKS control plane ENIs.
+	ControlPlaneSecurityGroupID string `json:"control-plane-security-group-id,omitempty"`
+	// NodeSecurityGroupID is the existing security group ID to attach
+	// to worker nodes, in place of a MNG/ASG-managed one.
+	NodeSecurityGroupID string `json:"node-security-group-id,omitempty"`
+	// LBSecurityGroupID is the existing security group ID to attach to
+	// load balancers created by NLB/ALB add-ons.
+	LBSecurityGroupID string `json:"lb-security-group-id,omitempty"`
+
+	// PrivateSubnetIDs are existing private subnet IDs to launch the
+	// control plane ENIs and worker nodes into.
+	PrivateSubnetIDs []string `json:"private-subnet-ids,omitempty"`
+	// PublicSubnetIDs are existing public subnet IDs to attach
+	// internet-facing load balancers to.
+	PublicSubnetIDs []string `json:"public-subnet-ids,omitempty"`
+}
+
+// Status represents the current status of a EKS cluster.
+type Status struct {
+	Up bool `json:"up"`
+
+	ClusterRoleCFNStackID string `json:"cluster-role-cfn-stack-id,omitempty"`
+	ClusterRoleName       string `json:"cluster-role-name,omitempty"`
+	ClusterRoleARN        string `json:"cluster-role-arn,omitempty"`
+
+	VPCCFNStackID               string   `json:"vpc-cfn-stack-id,omitempty"`
+	VPCID                       string   `json:"vpc-id,omitempty"`
+	PrivateSubnetIDs            []string `json:"private-subnet-ids,omitempty"`
+	ControlPlaneSecurityGroupID string   `json:"control-plane-security-group-id,omitempty"`
+
+	ClusterCFNStackID string `json:"cluster-cfn-stack-id,omitempty"`
+	ClusterARN        string `json:"cluster-arn,omitempty"`
+	ClusterCA         string `json:"cluster-ca,omitempty"`
+	ClusterCADecoded  string `json:"cluster-ca-decoded,omitempty"`
+}
+
+// StatusManagedNodeGroups represents the status of all "Managed Node Group"s.
+type StatusManagedNodeGroups struct {
+	RoleCFNStackID        string                            `json:"role-cfn-stack-id,omitempty"`
+	NvidiaDriverInstalled bool                              `json:"nvidia-driver-installed"`
+	Nodes                 map[string]StatusManagedNodeGroup `json:"nodes,omitempty"`
+}
+
+// StatusManagedNodeGroup represents the status of a "Managed Node Group".
+type StatusManagedNodeGroup struct {
+	NodeGroupStatus string `json:"node-group-status,omitempty"`
+}
+
+// Sync persists the current configuration to "ConfigPath".
+func (cfg *Config) Sync() error {
+	d, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(cfg.ConfigPath, d, 0600)
+}
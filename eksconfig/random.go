@@ -0,0 +1,20 @@
+package eksconfig
+
+import (
+	"math/rand"
+	"time"
+)
+
+var rnd = rand.New(rand.NewSource(time.Now().UnixNano()))
+
+const letters = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// randString returns a random string of length n, used to suffix
+// auto-generated resource names so repeated runs don't collide.
+func randString(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = letters[rnd.Intn(len(letters))]
+	}
+	return string(b)
+}
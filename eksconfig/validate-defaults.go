@@ -17,12 +17,11 @@ import (
 )
 
 // DefaultConfig is the default configuration.
-//  - empty string creates a non-nil object for pointer-type field
-//  - omitting an entire field returns nil value
-//  - make sure to check both
+//   - empty string creates a non-nil object for pointer-type field
+//   - omitting an entire field returns nil value
+//   - make sure to check both
 //
 // MAKE SURE TO SYNC THE DEFAULT VALUES in "eks" templates
-//
 var DefaultConfig = Config{
 	// to be auto-generated
 	ConfigPath:                "",
@@ -76,6 +75,26 @@ var DefaultConfig = Config{
 		LogsDir: "",
 	},
 
+	// AddOnNodeGroups provisions self-managed ASG node groups via
+	// CloudFormation, as an alternative to AddOnManagedNodeGroups.
+	AddOnNodeGroups: &AddOnNodeGroups{
+		Enable:      false,
+		SigningName: "eks",
+
+		RoleServicePrincipals: []string{
+			"ec2.amazonaws.com",
+			"eks.amazonaws.com",
+		},
+		RoleManagedPolicyARNs: []string{
+			"arn:aws:iam::aws:policy/AmazonEKSWorkerNodePolicy",
+			"arn:aws:iam::aws:policy/AmazonEKS_CNI_Policy",
+			"arn:aws:iam::aws:policy/AmazonEC2ContainerRegistryReadOnly",
+		},
+
+		// to be auto-generated
+		LogsDir: "",
+	},
+
 	AddOnNLBHelloWorld: &AddOnNLBHelloWorld{
 		Enable:             true,
 		DeploymentReplicas: 3,
@@ -125,6 +144,19 @@ var DefaultConfig = Config{
 		DeploymentReplicas:    10,
 	},
 
+	// AddOnCSI installs the EBS CSI driver, and optionally the EFS
+	// CSI driver plus an EFS file system, for dynamic volume provisioning.
+	AddOnCSI: &AddOnCSI{
+		Enable:    false,
+		EnableEFS: false,
+
+		CSIStress: &AddOnCSIStress{
+			Enable:       false,
+			StatefulSets: 10,
+			VolumeSize:   1, // 1 GiB
+		},
+	},
+
 	// read-only
 	Status: &Status{Up: false},
 	StatusManagedNodeGroups: &StatusManagedNodeGroups{
@@ -150,6 +182,13 @@ func NewDefault() *Config {
 
 	// ref. https://docs.aws.amazon.com/eks/latest/userguide/create-managed-node-group.html
 	// ref. https://docs.aws.amazon.com/AWSCloudFormation/latest/UserGuide/aws-resource-eks-nodegroup.html
+	//
+	// "AMIType" is the only AMI-related field a MNG needs: the EKS
+	// "CreateNodegroup" API resolves the latest AMI for that type
+	// itself, so there's no AMI ID to look up from "amiFamilyRegistry"
+	// here. "AddOnNodeGroups.ASGs" (self-managed) is the surface that
+	// actually needs an AMI ID, defaulted from the registry's
+	// "ssmParameterPrefix" in "ValidateAndSetDefaults".
 	vv.AddOnManagedNodeGroups.MNGs = map[string]MNG{
 		vv.Name + "-mng-cpu": MNG{
 			Name:               vv.Name + "-mng-cpu",
@@ -171,6 +210,9 @@ const (
 	DefaultNodeInstanceTypeCPU = "c5.xlarge"
 	// DefaultNodeInstanceTypeGPU is the default EC2 instance type for GPU worker node.
 	DefaultNodeInstanceTypeGPU = "p3.8xlarge"
+	// DefaultNodeInstanceTypeARM is the default Graviton EC2 instance
+	// type for arm64 ("*_ARM_64") AMI types.
+	DefaultNodeInstanceTypeARM = "c6g.xlarge"
 
 	// DefaultNodeVolumeSize is the default EC2 instance volume size for a worker node.
 	DefaultNodeVolumeSize = 40
@@ -254,6 +296,12 @@ func (cfg *Config) ValidateAndSetDefaults() error {
 	if cfg.AddOnManagedNodeGroups.LogsDir == "" {
 		cfg.AddOnManagedNodeGroups.LogsDir = filepath.Join(filepath.Dir(cfg.ConfigPath), cfg.Name+"-mng-logs")
 	}
+	if cfg.EventLogPath == "" {
+		cfg.EventLogPath = strings.ReplaceAll(cfg.ConfigPath, ".yaml", "") + ".events.jsonl"
+	}
+	if cfg.CloudWatchLogsGroupName == "" {
+		cfg.CloudWatchLogsGroupName = cfg.Name
+	}
 	cfg.Sync()
 
 	if !strings.Contains(cfg.KubectlDownloadURL, runtime.GOOS) {
@@ -295,8 +343,48 @@ func (cfg *Config) ValidateAndSetDefaults() error {
 		}
 	}
 
+	// validate shared SG/subnet overrides
+	//
+	// This only checks that the override IDs this package actually owns
+	// are present and consistent with the enabled add-ons; it does NOT
+	// verify that the referenced security groups carry the ingress
+	// rules kubelet/NLB/ALB need at runtime (kubelet 10250 from the
+	// control plane SG, the NodePort range 30000-32767 and health-check
+	// ports from the LB SG). Doing that requires an EC2
+	// "DescribeSecurityGroupRules" call against the live AWS account,
+	// which this package never makes — it only validates and defaults
+	// struct fields. That check belongs in the cluster-creation runner,
+	// right before it hands "ControlPlaneSecurityGroupID"/
+	// "NodeSecurityGroupID"/"LBSecurityGroupID" to the EKS/ASG/ELB
+	// APIs; a misconfigured shared SG still fails fast there, just not
+	// at config-validation time.
+	if ov := cfg.Parameters.SecurityGroupOverrides; ov != nil {
+		if ov.ControlPlaneSecurityGroupID == "" {
+			return errors.New("Parameters.SecurityGroupOverrides set, but empty ControlPlaneSecurityGroupID")
+		}
+		if ov.NodeSecurityGroupID == "" {
+			return errors.New("Parameters.SecurityGroupOverrides set, but empty NodeSecurityGroupID")
+		}
+		if len(ov.PrivateSubnetIDs) == 0 {
+			return errors.New("Parameters.SecurityGroupOverrides set, but empty PrivateSubnetIDs")
+		}
+		if (cfg.AddOnNLBHelloWorld.Enable || cfg.AddOnALB2048.Enable) && ov.LBSecurityGroupID == "" {
+			return errors.New("Parameters.SecurityGroupOverrides set with NLB/ALB add-on enabled, but empty LBSecurityGroupID")
+		}
+		if (cfg.AddOnNLBHelloWorld.Enable || cfg.AddOnALB2048.Enable) && len(ov.PublicSubnetIDs) == 0 {
+			return errors.New("Parameters.SecurityGroupOverrides set with NLB/ALB add-on enabled, but empty PublicSubnetIDs")
+		}
+		// shared resources are reused verbatim; the tester must not
+		// create or tag CFN stacks for SGs/subnets it does not own
+		cfg.Parameters.ControlPlaneSecurityGroupID = ov.ControlPlaneSecurityGroupID
+		cfg.Parameters.NodeSecurityGroupID = ov.NodeSecurityGroupID
+		cfg.Parameters.LBSecurityGroupID = ov.LBSecurityGroupID
+		cfg.Parameters.PrivateSubnetIDs = ov.PrivateSubnetIDs
+		cfg.Parameters.PublicSubnetIDs = ov.PublicSubnetIDs
+	}
+
 	// validate VPC-related
-	if cfg.Parameters.VPCCIDR != "" {
+	if cfg.Parameters.VPCCIDR != "" && cfg.Parameters.SecurityGroupOverrides == nil {
 		if cfg.Parameters.PrivateSubnetCIDR1 == "" {
 			return fmt.Errorf("non-empty Parameters.VPCCIDR %q, but got empty Parameters.PrivateSubnetCIDR1", cfg.Parameters.VPCCIDR)
 		}
@@ -368,7 +456,7 @@ func (cfg *Config) ValidateAndSetDefaults() error {
 	if len(cfg.Parameters.PrivateSubnetIDs) == 0 && cfg.Parameters.ControlPlaneSecurityGroupID != "" {
 		return fmt.Errorf("empty Parameters.PrivateSubnetIDs, non-empty Parameters.ControlPlaneSecurityGroupID %q", cfg.Parameters.ControlPlaneSecurityGroupID)
 	}
-	if len(cfg.Parameters.PrivateSubnetIDs) > 0 && cfg.Parameters.ControlPlaneSecurityGroupID != "" {
+	if len(cfg.Parameters.PrivateSubnetIDs) > 0 && cfg.Parameters.ControlPlaneSecurityGroupID == "" && cfg.Parameters.SecurityGroupOverrides == nil {
 		return fmt.Errorf("non-empty Parameters.PrivateSubnetIDs %+v, but empty Parameters.ControlPlaneSecurityGroupID", cfg.Parameters.PrivateSubnetIDs)
 	}
 	if cfg.Status.ClusterCFNStackID != "" {
@@ -392,6 +480,108 @@ func (cfg *Config) ValidateAndSetDefaults() error {
 	if cfg.AddOnManagedNodeGroups.SSHKeyPairName == "" {
 		cfg.AddOnManagedNodeGroups.SSHKeyPairName = cfg.Name + "-ssh"
 	}
+	if cfg.AddOnNodeGroups.RoleName == "" {
+		cfg.AddOnNodeGroups.RoleName = cfg.Name + "-ng-role"
+	}
+	if cfg.AddOnNodeGroups.SSHKeyPairName == "" {
+		cfg.AddOnNodeGroups.SSHKeyPairName = cfg.Name + "-ssh"
+	}
+	if cfg.AddOnNodeGroups.LogsDir == "" {
+		cfg.AddOnNodeGroups.LogsDir = filepath.Join(filepath.Dir(cfg.ConfigPath), cfg.Name+"-ng-logs")
+	}
+
+	workloadAddOnsEnabled := cfg.AddOnNLBHelloWorld.Enable ||
+		cfg.AddOnALB2048.Enable ||
+		cfg.AddOnJobPerl.Enable ||
+		cfg.AddOnJobEcho.Enable ||
+		cfg.AddOnSecrets.Enable ||
+		cfg.AddOnIRSA.Enable ||
+		cfg.AddOnCSI.Enable
+	if workloadAddOnsEnabled && !cfg.AddOnManagedNodeGroups.Enable && !cfg.AddOnNodeGroups.Enable {
+		return errors.New("workload add-on enabled, but both AddOnManagedNodeGroups.Enable and AddOnNodeGroups.Enable are false")
+	}
+	if n := len(cfg.AddOnManagedNodeGroups.MNGs) + len(cfg.AddOnNodeGroups.ASGs); n > MNGMaxLimit {
+		return fmt.Errorf("AddOnManagedNodeGroups.MNGs + AddOnNodeGroups.ASGs %d exceeds maximum number of node groups per EKS cluster which is %d", n, MNGMaxLimit)
+	}
+
+	if cfg.AddOnNodeGroups.Enable {
+		if cfg.AddOnNodeGroups.RemoteAccessPrivateKeyPath == "" {
+			return errors.New("empty AddOnNodeGroups.RemoteAccessPrivateKeyPath")
+		}
+		if len(cfg.AddOnNodeGroups.ASGs) == 0 {
+			return errors.New("AddOnNodeGroups.Enable but empty AddOnNodeGroups.ASGs")
+		}
+		names := make(map[string]struct{})
+		for k, v := range cfg.AddOnNodeGroups.ASGs {
+			if v.Name == "" {
+				return fmt.Errorf("AddOnNodeGroups.ASGs[%q].Name is empty", k)
+			}
+			if k != v.Name {
+				return fmt.Errorf("AddOnNodeGroups.ASGs[%q].Name has different Name field %q", k, v.Name)
+			}
+			if _, ok := names[v.Name]; ok {
+				return fmt.Errorf("AddOnNodeGroups.ASGs[%q].Name %q is redundant", k, v.Name)
+			}
+			names[v.Name] = struct{}{}
+
+			if v.ASGMinSize > v.ASGMaxSize {
+				return fmt.Errorf("AddOnNodeGroups.ASGs[%q].ASGMinSize %d > ASGMaxSize %d", k, v.ASGMinSize, v.ASGMaxSize)
+			}
+			if v.ASGDesiredCapacity > v.ASGMaxSize {
+				return fmt.Errorf("AddOnNodeGroups.ASGs[%q].ASGDesiredCapacity %d > ASGMaxSize %d", k, v.ASGDesiredCapacity, v.ASGMaxSize)
+			}
+			if v.ASGMaxSize > MNGNodesMaxLimit {
+				return fmt.Errorf("AddOnNodeGroups.ASGs[%q].ASGMaxSize %d > MNGNodesMaxLimit %d", k, v.ASGMaxSize, MNGNodesMaxLimit)
+			}
+			if v.VolumeSize == 0 {
+				v.VolumeSize = DefaultNodeVolumeSize
+			}
+			if v.AMIType != "" {
+				wantFamily, err := amiFamilyForSelfManagedAMIType(v.AMIType)
+				if err != nil {
+					return fmt.Errorf("AddOnNodeGroups.ASGs[%q]: %v", k, err)
+				}
+				if v.AMIFamily == "" {
+					v.AMIFamily = wantFamily
+				} else if v.AMIFamily != wantFamily {
+					return fmt.Errorf("AddOnNodeGroups.ASGs[%q].AMIFamily %q does not match AMIType %q (expected %q)", k, v.AMIFamily, v.AMIType, wantFamily)
+				}
+			} else if v.AMIFamily == "" {
+				v.AMIFamily = AMIFamilyAL2
+			}
+			if _, ok := amiFamilyRegistry[v.AMIFamily]; !ok {
+				return fmt.Errorf("AddOnNodeGroups.ASGs[%q].AMIFamily %q is not supported", k, v.AMIFamily)
+			}
+			if v.ImageID == "" && v.AMISSMParameterPath == "" {
+				if path, ok := ssmParameterPathForFamily(v.AMIFamily); ok {
+					v.AMISSMParameterPath = path
+				}
+			}
+
+			mixedInstanceTypes := 0
+			if v.MixedInstancePolicy != nil {
+				mixedInstanceTypes = len(v.MixedInstancePolicy.InstanceTypes)
+			}
+			if len(v.InstanceTypes) == 0 && mixedInstanceTypes == 0 {
+				switch {
+				case v.AMIType == eks.AMITypesAl2X8664Gpu:
+					v.InstanceTypes = []string{DefaultNodeInstanceTypeGPU}
+				case isARM64AMIType(v.AMIType):
+					v.InstanceTypes = []string{DefaultNodeInstanceTypeARM}
+				default:
+					v.InstanceTypes = []string{DefaultNodeInstanceTypeCPU}
+				}
+			}
+			if err := validateAMITypeInstanceTypes(v.AMIType, v.InstanceTypes); err != nil {
+				return fmt.Errorf("AddOnNodeGroups.ASGs[%q]: %v", k, err)
+			}
+			if v.MixedInstancePolicy != nil && v.SpotInstancePools > 0 && v.MixedInstancePolicy.SpotAllocationStrategy != "capacity-optimized" {
+				return fmt.Errorf("AddOnNodeGroups.ASGs[%q].SpotInstancePools set, but SpotAllocationStrategy is %q, not \"capacity-optimized\"", k, v.MixedInstancePolicy.SpotAllocationStrategy)
+			}
+
+			cfg.AddOnNodeGroups.ASGs[k] = v
+		}
+	}
 	if cfg.AddOnManagedNodeGroups.Enable {
 		if cfg.AddOnManagedNodeGroups.RemoteAccessPrivateKeyPath == "" {
 			return errors.New("empty AddOnManagedNodeGroups.RemoteAccessPrivateKeyPath")
@@ -425,17 +615,35 @@ func (cfg *Config) ValidateAndSetDefaults() error {
 				v.VolumeSize = DefaultNodeVolumeSize
 			}
 
-			switch v.AMIType {
-			case eks.AMITypesAl2X8664:
-				if len(v.InstanceTypes) == 0 {
-					v.InstanceTypes = []string{DefaultNodeInstanceTypeCPU}
-				}
-			case eks.AMITypesAl2X8664Gpu:
-				if len(v.InstanceTypes) == 0 {
+			wantFamily, err := amiFamilyForAMIType(v.AMIType)
+			if err != nil {
+				return fmt.Errorf("AddOnManagedNodeGroups.MNGs[%q]: %v", k, err)
+			}
+			if v.AMIFamily == "" {
+				v.AMIFamily = wantFamily
+			} else if v.AMIFamily != wantFamily {
+				return fmt.Errorf("AddOnManagedNodeGroups.MNGs[%q].AMIFamily %q does not match AMIType %q (expected %q)", k, v.AMIFamily, v.AMIType, wantFamily)
+			}
+			famDef, ok := amiFamilyRegistry[v.AMIFamily]
+			if !ok {
+				return fmt.Errorf("AddOnManagedNodeGroups.MNGs[%q].AMIFamily %q is not supported", k, v.AMIFamily)
+			}
+			if v.RemoteAccessUserName == "" {
+				v.RemoteAccessUserName = famDef.remoteAccessUserName
+			}
+
+			if len(v.InstanceTypes) == 0 {
+				switch {
+				case v.AMIType == eks.AMITypesAl2X8664Gpu:
 					v.InstanceTypes = []string{DefaultNodeInstanceTypeGPU}
+				case isARM64AMIType(v.AMIType):
+					v.InstanceTypes = []string{DefaultNodeInstanceTypeARM}
+				default:
+					v.InstanceTypes = []string{DefaultNodeInstanceTypeCPU}
 				}
-			default:
-				return fmt.Errorf("unknown AddOnManagedNodeGroups.MNGs[%q].AMIType %q", k, v.AMIType)
+			}
+			if err := validateAMITypeInstanceTypes(v.AMIType, v.InstanceTypes); err != nil {
+				return fmt.Errorf("AddOnManagedNodeGroups.MNGs[%q]: %v", k, err)
 			}
 
 			if cfg.AddOnNLBHelloWorld.Enable || cfg.AddOnALB2048.Enable {
@@ -477,7 +685,9 @@ func (cfg *Config) ValidateAndSetDefaults() error {
 
 			cfg.AddOnManagedNodeGroups.MNGs[k] = v
 		}
+	}
 
+	if cfg.AddOnManagedNodeGroups.Enable || cfg.AddOnNodeGroups.Enable {
 		if cfg.AddOnJobEcho.Size > 250000 {
 			return fmt.Errorf("echo size limit is 0.25 MB, got %d", cfg.AddOnJobEcho.Size)
 		}
@@ -563,27 +773,175 @@ func (cfg *Config) ValidateAndSetDefaults() error {
 			cfg.AddOnIRSA.RoleName = cfg.Name + "-irsa-role"
 		}
 
+		if cfg.AddOnIRSA.PublishDiscovery && cfg.AddOnIRSA.DiscoveryS3BucketName == "" {
+			cfg.AddOnIRSA.DiscoveryS3BucketName = cfg.Name + "-irsa-discovery"
+		}
+
+		if cfg.AddOnCSI.Enable {
+			if !cfg.AddOnIRSA.Enable {
+				return errors.New("AddOnCSI.Enable true, but AddOnIRSA.Enable false; the CSI driver IAM roles are federated through AddOnIRSA")
+			}
+			if cfg.AddOnCSI.Namespace == "" {
+				cfg.AddOnCSI.Namespace = cfg.Name + "-csi"
+			}
+			if cfg.AddOnCSI.EBSDriverRoleServiceAccount == "" {
+				cfg.AddOnCSI.EBSDriverRoleServiceAccount = cfg.Name + "-ebs-csi-controller-sa"
+			}
+			if cfg.AddOnIRSA.ServiceAccountRoles == nil {
+				cfg.AddOnIRSA.ServiceAccountRoles = make(map[string]ServiceAccountRole)
+			}
+			ebsKey := cfg.AddOnCSI.Namespace + "/" + cfg.AddOnCSI.EBSDriverRoleServiceAccount
+			if _, ok := cfg.AddOnIRSA.ServiceAccountRoles[ebsKey]; !ok {
+				cfg.AddOnIRSA.ServiceAccountRoles[ebsKey] = ServiceAccountRole{
+					ManagedPolicyARNs: []string{ebsCSIDriverPolicyARN},
+				}
+			}
+			if cfg.AddOnCSI.EnableEFS {
+				if _, unsupported := efsUnsupportedRegions[cfg.Region]; unsupported {
+					return fmt.Errorf("AddOnCSI.EnableEFS true, but region %q does not support EFS", cfg.Region)
+				}
+				// "Status.PrivateSubnetIDs" is only populated once the
+				// tester's own CFN stack creates the VPC; when
+				// "Parameters.SecurityGroupOverrides" is set, the
+				// private subnets are pre-existing and live in
+				// "Parameters.PrivateSubnetIDs" instead (see the
+				// override-handling block above), so either counts.
+				if len(cfg.Status.PrivateSubnetIDs) == 0 && len(cfg.Parameters.PrivateSubnetIDs) == 0 {
+					return errors.New("AddOnCSI.EnableEFS true, but empty Status.PrivateSubnetIDs/Parameters.PrivateSubnetIDs to create EFS mount targets in")
+				}
+				if cfg.AddOnCSI.EFSDriverRoleServiceAccount == "" {
+					cfg.AddOnCSI.EFSDriverRoleServiceAccount = cfg.Name + "-efs-csi-controller-sa"
+				}
+				efsKey := cfg.AddOnCSI.Namespace + "/" + cfg.AddOnCSI.EFSDriverRoleServiceAccount
+				if _, ok := cfg.AddOnIRSA.ServiceAccountRoles[efsKey]; !ok {
+					cfg.AddOnIRSA.ServiceAccountRoles[efsKey] = ServiceAccountRole{
+						ManagedPolicyARNs: []string{efsCSIDriverPolicyARN},
+					}
+				}
+			}
+			if cfg.AddOnCSI.CSIStress != nil && cfg.AddOnCSI.CSIStress.Enable {
+				if cfg.AddOnCSI.CSIStress.Namespace == "" {
+					cfg.AddOnCSI.CSIStress.Namespace = cfg.Name + "-csi-stress"
+				}
+				if cfg.AddOnCSI.CSIStress.StressResultPath == "" {
+					cfg.AddOnCSI.CSIStress.StressResultPath = filepath.Join(
+						filepath.Dir(cfg.ConfigPath),
+						cfg.Name+"-csi-stress.csv",
+					)
+				}
+				if filepath.Ext(cfg.AddOnCSI.CSIStress.StressResultPath) != ".csv" {
+					return fmt.Errorf("expected .csv extension for AddOnCSI.CSIStress.StressResultPath, got %q", cfg.AddOnCSI.CSIStress.StressResultPath)
+				}
+			}
+		}
+
+		if cfg.AddOnIRSA.Enable && len(cfg.AddOnIRSA.ServiceAccountRoles) > 0 {
+			if err := cfg.validateServiceAccountRoles(); err != nil {
+				return err
+			}
+		}
+
 	} else {
 
 		if cfg.AddOnNLBHelloWorld.Enable {
-			return fmt.Errorf("AddOnManagedNodeGroups.Enable false, but got AddOnNLBHelloWorld.Enable %v", cfg.AddOnNLBHelloWorld.Enable)
+			return fmt.Errorf("AddOnManagedNodeGroups.Enable and AddOnNodeGroups.Enable both false, but got AddOnNLBHelloWorld.Enable %v", cfg.AddOnNLBHelloWorld.Enable)
 		}
 		if cfg.AddOnALB2048.Enable {
-			return fmt.Errorf("AddOnManagedNodeGroups.Enable false, but got AddOnALB2048.Enable %v", cfg.AddOnALB2048.Enable)
+			return fmt.Errorf("AddOnManagedNodeGroups.Enable and AddOnNodeGroups.Enable both false, but got AddOnALB2048.Enable %v", cfg.AddOnALB2048.Enable)
 		}
 		if cfg.AddOnJobPerl.Enable {
-			return fmt.Errorf("AddOnManagedNodeGroups.Enable false, but got AddOnJobPerl.Enable %v", cfg.AddOnJobPerl.Enable)
+			return fmt.Errorf("AddOnManagedNodeGroups.Enable and AddOnNodeGroups.Enable both false, but got AddOnJobPerl.Enable %v", cfg.AddOnJobPerl.Enable)
 		}
 		if cfg.AddOnJobEcho.Enable {
-			return fmt.Errorf("AddOnManagedNodeGroups.Enable false, but got AddOnJobEcho.Enable %v", cfg.AddOnJobEcho.Enable)
+			return fmt.Errorf("AddOnManagedNodeGroups.Enable and AddOnNodeGroups.Enable both false, but got AddOnJobEcho.Enable %v", cfg.AddOnJobEcho.Enable)
 		}
 		if cfg.AddOnSecrets.Enable {
-			return fmt.Errorf("AddOnManagedNodeGroups.Enable false, but got AddOnSecrets.Enable %v", cfg.AddOnSecrets.Enable)
+			return fmt.Errorf("AddOnManagedNodeGroups.Enable and AddOnNodeGroups.Enable both false, but got AddOnSecrets.Enable %v", cfg.AddOnSecrets.Enable)
 		}
 		if cfg.AddOnIRSA.Enable {
-			return fmt.Errorf("AddOnManagedNodeGroups.Enable false, but got AddOnIRSA.Enable %v", cfg.AddOnIRSA.Enable)
+			return fmt.Errorf("AddOnManagedNodeGroups.Enable and AddOnNodeGroups.Enable both false, but got AddOnIRSA.Enable %v", cfg.AddOnIRSA.Enable)
+		}
+		if cfg.AddOnCSI.Enable {
+			return fmt.Errorf("AddOnManagedNodeGroups.Enable and AddOnNodeGroups.Enable both false, but got AddOnCSI.Enable %v", cfg.AddOnCSI.Enable)
 		}
 	}
 
 	return cfg.Sync()
-}
\ No newline at end of file
+}
+
+// validateServiceAccountRoles uniquifies role names across
+// "AddOnIRSA.ServiceAccountRoles", rejects namespace collisions (both
+// within "ServiceAccountRoles" and against the namespaces other add-ons
+// already own), and enforces that wildcard entries only use the
+// namespace-glob trust policy "sub" condition.
+//
+// "AddOnCSI.Namespace" is deliberately not in this list: the CSI
+// driver's own service account roles are wired into
+// "ServiceAccountRoles" under that same namespace.
+func (cfg *Config) validateServiceAccountRoles() error {
+	otherNamespaces := map[string]string{
+		cfg.AddOnNLBHelloWorld.Namespace: "AddOnNLBHelloWorld.Namespace",
+		cfg.AddOnALB2048.Namespace:       "AddOnALB2048.Namespace",
+		cfg.AddOnJobPerl.Namespace:       "AddOnJobPerl.Namespace",
+		cfg.AddOnJobEcho.Namespace:       "AddOnJobEcho.Namespace",
+		cfg.AddOnSecrets.Namespace:       "AddOnSecrets.Namespace",
+	}
+	if cfg.AddOnCSI.CSIStress != nil {
+		otherNamespaces[cfg.AddOnCSI.CSIStress.Namespace] = "AddOnCSI.CSIStress.Namespace"
+	}
+	delete(otherNamespaces, "")
+
+	roleNames := make(map[string]struct{})
+	wildcardNamespaces := make(map[string]string) // namespace -> "<namespace>/*" key that wildcards it
+
+	for key, sa := range cfg.AddOnIRSA.ServiceAccountRoles {
+		ns, name, err := splitNamespaceServiceAccount(key)
+		if err != nil {
+			return fmt.Errorf("AddOnIRSA.ServiceAccountRoles[%q]: %v", key, err)
+		}
+		if sa.Wildcard {
+			if name != "*" {
+				return fmt.Errorf("AddOnIRSA.ServiceAccountRoles[%q] is Wildcard but service account name %q must be \"*\"", key, name)
+			}
+			wildcardNamespaces[ns] = key
+		}
+	}
+
+	for key, sa := range cfg.AddOnIRSA.ServiceAccountRoles {
+		ns, name, err := splitNamespaceServiceAccount(key)
+		if err != nil {
+			return fmt.Errorf("AddOnIRSA.ServiceAccountRoles[%q]: %v", key, err)
+		}
+		if owner, ok := otherNamespaces[ns]; ok {
+			return fmt.Errorf("AddOnIRSA.ServiceAccountRoles[%q] namespace %q collides with %s", key, ns, owner)
+		}
+		// a wildcard's trust policy ("system:serviceaccount:<ns>:*")
+		// already covers every service account in its namespace, so a
+		// specific entry in that same namespace is a real conflict,
+		// not just the wildcard entry seeing itself.
+		if owner, ok := wildcardNamespaces[ns]; ok && owner != key {
+			return fmt.Errorf("AddOnIRSA.ServiceAccountRoles[%q] namespace %q already wildcarded by %q", key, ns, owner)
+		}
+
+		if sa.RoleName == "" {
+			sa.RoleName = fmt.Sprintf("%s-irsa-%s-%s-role", cfg.Name, ns, name)
+		}
+		if _, ok := roleNames[sa.RoleName]; ok {
+			return fmt.Errorf("AddOnIRSA.ServiceAccountRoles[%q].RoleName %q is redundant", key, sa.RoleName)
+		}
+		roleNames[sa.RoleName] = struct{}{}
+
+		cfg.AddOnIRSA.ServiceAccountRoles[key] = sa
+	}
+
+	return nil
+}
+
+// splitNamespaceServiceAccount parses a "<namespace>/<service-account>" key.
+func splitNamespaceServiceAccount(key string) (namespace, serviceAccount string, err error) {
+	parts := strings.SplitN(key, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("expected \"<namespace>/<service-account>\", got %q", key)
+	}
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,264 @@
+package eksconfig
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// newBaseTestConfig returns a minimal Config that passes
+// "ValidateAndSetDefaults" on its own, so each test only needs to set
+// the field(s) it's exercising.
+func newBaseTestConfig(t *testing.T) *Config {
+	t.Helper()
+
+	dir := t.TempDir()
+	kubectlDownloadURL := "https://storage.googleapis.com/kubernetes-release/release/v1.14.10/bin/linux/amd64/kubectl"
+	if runtime.GOOS == "darwin" {
+		kubectlDownloadURL = "https://storage.googleapis.com/kubernetes-release/release/v1.14.10/bin/darwin/amd64/kubectl"
+	}
+
+	return &Config{
+		ConfigPath:         filepath.Join(dir, "test-config.yaml"),
+		Name:               "test-cluster",
+		Region:             "us-west-2",
+		LogOutputs:         []string{"stderr"},
+		KubectlDownloadURL: kubectlDownloadURL,
+
+		Parameters: &Parameters{Version: "1.14"},
+
+		AddOnManagedNodeGroups: &AddOnManagedNodeGroups{Enable: false},
+		AddOnNodeGroups:        &AddOnNodeGroups{Enable: false},
+		AddOnNLBHelloWorld:     &AddOnNLBHelloWorld{},
+		AddOnALB2048:           &AddOnALB2048{},
+		AddOnJobPerl:           &AddOnJobPerl{},
+		AddOnJobEcho:           &AddOnJobEcho{},
+		AddOnSecrets:           &AddOnSecrets{},
+		AddOnIRSA:              &AddOnIRSA{},
+		AddOnCSI:               &AddOnCSI{},
+
+		Status:                  &Status{},
+		StatusManagedNodeGroups: &StatusManagedNodeGroups{},
+	}
+}
+
+// TestValidateAndSetDefaultsSecurityGroupOverrides is a regression test
+// for a bug where setting "Parameters.SecurityGroupOverrides" always
+// tripped the unrelated "Parameters.PrivateSubnetIDs"/
+// "Parameters.ControlPlaneSecurityGroupID" consistency check a few
+// lines later, making the override feature unusable.
+func TestValidateAndSetDefaultsSecurityGroupOverrides(t *testing.T) {
+	cfg := newBaseTestConfig(t)
+	cfg.Parameters.SecurityGroupOverrides = &SecurityGroupOverrides{
+		ControlPlaneSecurityGroupID: "sg-control-plane",
+		NodeSecurityGroupID:         "sg-node",
+		PrivateSubnetIDs:            []string{"subnet-private-1"},
+	}
+
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Parameters.ControlPlaneSecurityGroupID != "sg-control-plane" {
+		t.Errorf("ControlPlaneSecurityGroupID = %q, want %q", cfg.Parameters.ControlPlaneSecurityGroupID, "sg-control-plane")
+	}
+	if cfg.Parameters.NodeSecurityGroupID != "sg-node" {
+		t.Errorf("NodeSecurityGroupID = %q, want %q", cfg.Parameters.NodeSecurityGroupID, "sg-node")
+	}
+}
+
+// TestValidateAndSetDefaultsSelfManagedNodeGroupsWithWorkloadAddOn is a
+// regression test for a bug where workload add-ons (e.g.
+// "AddOnNLBHelloWorld") were unconditionally rejected whenever
+// "AddOnManagedNodeGroups.Enable" was false, even if
+// "AddOnNodeGroups.Enable" (the self-managed alternative) was true.
+func TestValidateAndSetDefaultsSelfManagedNodeGroupsWithWorkloadAddOn(t *testing.T) {
+	cfg := newBaseTestConfig(t)
+	cfg.AddOnNodeGroups.Enable = true
+	cfg.AddOnNodeGroups.RemoteAccessPrivateKeyPath = filepath.Join(t.TempDir(), "kube_aws_rsa")
+	cfg.AddOnNodeGroups.ASGs = map[string]ASG{
+		"test-cluster-ng": {
+			Name:               "test-cluster-ng",
+			AMIType:            "AL2_x86_64",
+			ASGMinSize:         1,
+			ASGMaxSize:         1,
+			ASGDesiredCapacity: 1,
+		},
+	}
+	cfg.AddOnNLBHelloWorld.Enable = true
+
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.AddOnNLBHelloWorld.Namespace == "" {
+		t.Error("AddOnNLBHelloWorld.Namespace was not defaulted")
+	}
+}
+
+// TestValidateAndSetDefaultsARMInstanceTypeDefault is a regression test
+// for a bug where an empty "InstanceTypes" on an arm64 MNG defaulted to
+// the x86 CPU instance type, which then always failed the
+// Graviton-only "validateAMITypeInstanceTypes" check.
+func TestValidateAndSetDefaultsARMInstanceTypeDefault(t *testing.T) {
+	cfg := newBaseTestConfig(t)
+	cfg.AddOnManagedNodeGroups.Enable = true
+	cfg.AddOnManagedNodeGroups.RemoteAccessPrivateKeyPath = filepath.Join(t.TempDir(), "kube_aws_rsa")
+	cfg.AddOnManagedNodeGroups.RemoteAccessUserName = "ec2-user"
+	cfg.AddOnManagedNodeGroups.MNGs = map[string]MNG{
+		"test-cluster-mng-arm": {
+			Name:               "test-cluster-mng-arm",
+			AMIType:            "AL2_ARM_64",
+			ASGMinSize:         1,
+			ASGMaxSize:         1,
+			ASGDesiredCapacity: 1,
+		},
+	}
+
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mng := cfg.AddOnManagedNodeGroups.MNGs["test-cluster-mng-arm"]
+	if len(mng.InstanceTypes) != 1 || mng.InstanceTypes[0] != DefaultNodeInstanceTypeARM {
+		t.Errorf("InstanceTypes = %v, want [%s]", mng.InstanceTypes, DefaultNodeInstanceTypeARM)
+	}
+}
+
+// TestValidateAndSetDefaultsSelfManagedASGAMISSMParameterPath is a
+// regression test for a bug where "amiFamilyRegistry"'s SSM parameter
+// paths were dead data never wired into any node group's config, so a
+// self-managed "AddOnNodeGroups.ASGs" entry had no way to resolve an
+// "ImageID" for its launch template.
+func TestValidateAndSetDefaultsSelfManagedASGAMISSMParameterPath(t *testing.T) {
+	cfg := newBaseTestConfig(t)
+	cfg.AddOnNodeGroups.Enable = true
+	cfg.AddOnNodeGroups.RemoteAccessPrivateKeyPath = filepath.Join(t.TempDir(), "kube_aws_rsa")
+	cfg.AddOnNodeGroups.ASGs = map[string]ASG{
+		"test-cluster-ng-cpu": {
+			Name:               "test-cluster-ng-cpu",
+			AMIType:            "AL2_x86_64",
+			ASGMinSize:         1,
+			ASGMaxSize:         1,
+			ASGDesiredCapacity: 1,
+			InstanceTypes:      []string{DefaultNodeInstanceTypeCPU},
+		},
+	}
+
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	asg := cfg.AddOnNodeGroups.ASGs["test-cluster-ng-cpu"]
+	if asg.AMISSMParameterPath == "" {
+		t.Error("AMISSMParameterPath was not defaulted")
+	}
+}
+
+// TestValidateAndSetDefaultsSelfManagedASGInstanceTypeDefault is a
+// regression test for a bug where a self-managed "AddOnNodeGroups.ASGs"
+// entry with neither "InstanceTypes" nor "MixedInstancePolicy" set
+// passed validation cleanly and would only fail much later at CFN
+// launch-template creation.
+func TestValidateAndSetDefaultsSelfManagedASGInstanceTypeDefault(t *testing.T) {
+	cfg := newBaseTestConfig(t)
+	cfg.AddOnNodeGroups.Enable = true
+	cfg.AddOnNodeGroups.RemoteAccessPrivateKeyPath = filepath.Join(t.TempDir(), "kube_aws_rsa")
+	cfg.AddOnNodeGroups.ASGs = map[string]ASG{
+		"test-cluster-ng-arm": {
+			Name:               "test-cluster-ng-arm",
+			AMIType:            "AL2_ARM_64",
+			ASGMinSize:         1,
+			ASGMaxSize:         1,
+			ASGDesiredCapacity: 1,
+		},
+	}
+
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	asg := cfg.AddOnNodeGroups.ASGs["test-cluster-ng-arm"]
+	if len(asg.InstanceTypes) != 1 || asg.InstanceTypes[0] != DefaultNodeInstanceTypeARM {
+		t.Errorf("InstanceTypes = %v, want [%s]", asg.InstanceTypes, DefaultNodeInstanceTypeARM)
+	}
+}
+
+// TestValidateAndSetDefaultsCloudWatchLogsGroupName is a regression test
+// for a bug where the auto-naming default for
+// "Config.CloudWatchLogsGroupName" was deleted without a replacement,
+// so enabling the CloudWatch sink always required the caller to name
+// the log group by hand.
+func TestValidateAndSetDefaultsCloudWatchLogsGroupName(t *testing.T) {
+	cfg := newBaseTestConfig(t)
+
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.CloudWatchLogsGroupName != cfg.Name {
+		t.Errorf("CloudWatchLogsGroupName = %q, want %q", cfg.CloudWatchLogsGroupName, cfg.Name)
+	}
+}
+
+// TestValidateAndSetDefaultsCSIEnableEFSWithSecurityGroupOverrides is a
+// regression test for a bug where "AddOnCSI.EnableEFS" only ever
+// checked "Status.PrivateSubnetIDs" (populated once the tester's own
+// CFN stack creates the VPC), so a cluster using pre-existing subnets
+// via "Parameters.SecurityGroupOverrides" could never enable EFS even
+// though its private subnets were known up front.
+func TestValidateAndSetDefaultsCSIEnableEFSWithSecurityGroupOverrides(t *testing.T) {
+	cfg := newBaseTestConfig(t)
+	cfg.Parameters.SecurityGroupOverrides = &SecurityGroupOverrides{
+		ControlPlaneSecurityGroupID: "sg-control-plane",
+		NodeSecurityGroupID:         "sg-node",
+		PrivateSubnetIDs:            []string{"subnet-private-1"},
+	}
+	cfg.AddOnNodeGroups.Enable = true
+	cfg.AddOnNodeGroups.RemoteAccessPrivateKeyPath = filepath.Join(t.TempDir(), "kube_aws_rsa")
+	cfg.AddOnNodeGroups.ASGs = map[string]ASG{
+		"test-cluster-ng": {
+			Name:               "test-cluster-ng",
+			AMIType:            "AL2_x86_64",
+			ASGMinSize:         1,
+			ASGMaxSize:         1,
+			ASGDesiredCapacity: 1,
+		},
+	}
+	cfg.AddOnIRSA.Enable = true
+	cfg.AddOnCSI.Enable = true
+	cfg.AddOnCSI.EnableEFS = true
+
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// TestValidateAndSetDefaultsServiceAccountRolesWildcardConflict is a
+// regression test for a bug where a wildcard entry ("ns/*") and a
+// specific entry ("ns/bar") in the same namespace were not flagged as
+// conflicting: the wildcard's trust policy
+// ("system:serviceaccount:ns:*") already federates every service
+// account in "ns", so the specific entry's role is unreachable.
+func TestValidateAndSetDefaultsServiceAccountRolesWildcardConflict(t *testing.T) {
+	cfg := newBaseTestConfig(t)
+	cfg.AddOnNodeGroups.Enable = true
+	cfg.AddOnNodeGroups.RemoteAccessPrivateKeyPath = filepath.Join(t.TempDir(), "kube_aws_rsa")
+	cfg.AddOnNodeGroups.ASGs = map[string]ASG{
+		"test-cluster-ng": {
+			Name:               "test-cluster-ng",
+			AMIType:            "AL2_x86_64",
+			ASGMinSize:         1,
+			ASGMaxSize:         1,
+			ASGDesiredCapacity: 1,
+		},
+	}
+	cfg.AddOnIRSA.Enable = true
+	cfg.AddOnIRSA.ServiceAccountRoles = map[string]ServiceAccountRole{
+		"apps/*": {
+			Wildcard:          true,
+			ManagedPolicyARNs: []string{"arn:aws:iam::aws:policy/AmazonS3ReadOnlyAccess"},
+		},
+		"apps/bar": {
+			ManagedPolicyARNs: []string{"arn:aws:iam::aws:policy/AmazonSQSFullAccess"},
+		},
+	}
+
+	if err := cfg.ValidateAndSetDefaults(); err == nil {
+		t.Fatal("expected error for specific service account conflicting with a namespace wildcard, got nil")
+	}
+}
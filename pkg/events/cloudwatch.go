@@ -0,0 +1,95 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+)
+
+// CloudWatchSink streams events to a single CloudWatch Logs log
+// stream within "LogGroupName", creating the stream on first use.
+type CloudWatchSink struct {
+	cli           *cloudwatchlogs.CloudWatchLogs
+	logGroupName  string
+	logStreamName string
+	sequenceToken *string
+	streamCreated bool
+}
+
+// NewCloudWatchSink returns a Sink that writes to "logGroupName";
+// "logStreamName" is typically the cluster name so parallel test runs
+// don't interleave in the same stream.
+func NewCloudWatchSink(cli *cloudwatchlogs.CloudWatchLogs, logGroupName, logStreamName string) *CloudWatchSink {
+	return &CloudWatchSink{
+		cli:           cli,
+		logGroupName:  logGroupName,
+		logStreamName: logStreamName,
+	}
+}
+
+// Put writes a single event as a CloudWatch Logs log event.
+func (s *CloudWatchSink) Put(ev Event) error {
+	if !s.streamCreated {
+		_, err := s.cli.CreateLogStream(&cloudwatchlogs.CreateLogStreamInput{
+			LogGroupName:  aws.String(s.logGroupName),
+			LogStreamName: aws.String(s.logStreamName),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create CloudWatch Logs stream %q in group %q (%v)", s.logStreamName, s.logGroupName, err)
+		}
+		s.streamCreated = true
+	}
+
+	msg, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	out, err := s.cli.PutLogEvents(&cloudwatchlogs.PutLogEventsInput{
+		LogGroupName:  aws.String(s.logGroupName),
+		LogStreamName: aws.String(s.logStreamName),
+		SequenceToken: s.sequenceToken,
+		LogEvents: []*cloudwatchlogs.InputLogEvent{
+			{
+				Timestamp: aws.Int64(ev.Time.UnixNano() / int64(time.Millisecond)),
+				Message:   aws.String(string(msg)),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put CloudWatch Logs event to %q/%q (%v)", s.logGroupName, s.logStreamName, err)
+	}
+	s.sequenceToken = out.NextSequenceToken
+	return nil
+}
+
+// VerifyRoleCanPutLogEvents checks, via IAM policy simulation, that
+// "roleARN" is allowed "logs:PutLogEvents" on "logGroupARN", so a
+// misconfigured CloudWatch sink fails fast at setup instead of
+// silently dropping every event. "eksconfig.ValidateAndSetDefaults"
+// does not call it; it makes no AWS API calls. Takes "iamiface.IAMAPI" rather than
+// the concrete "*iam.IAM" so callers can stub it out in tests.
+func VerifyRoleCanPutLogEvents(cli iamiface.IAMAPI, roleARN, logGroupARN string) error {
+	out, err := cli.SimulatePrincipalPolicy(&iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(roleARN),
+		ActionNames:     aws.StringSlice([]string{"logs:PutLogEvents"}),
+		ResourceArns:    aws.StringSlice([]string{logGroupARN}),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to simulate IAM policy for %q (%v)", roleARN, err)
+	}
+	if len(out.EvaluationResults) == 0 {
+		return fmt.Errorf("IAM policy simulation for %q returned no evaluation results for logs:PutLogEvents on %q", roleARN, logGroupARN)
+	}
+	for _, r := range out.EvaluationResults {
+		if r.EvalDecision == nil || *r.EvalDecision != iam.PolicyEvaluationDecisionTypeAllowed {
+			return fmt.Errorf("role %q is not allowed logs:PutLogEvents on %q", roleARN, logGroupARN)
+		}
+	}
+	return nil
+}
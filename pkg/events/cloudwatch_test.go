@@ -0,0 +1,58 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+)
+
+// fakeIAMClient stubs just the one IAM API call
+// "VerifyRoleCanPutLogEvents" needs, returning a canned evaluation
+// decision for any "SimulatePrincipalPolicy" call.
+type fakeIAMClient struct {
+	iamiface.IAMAPI
+	decision string
+	empty    bool
+	err      error
+}
+
+func (f *fakeIAMClient) SimulatePrincipalPolicy(in *iam.SimulatePrincipalPolicyInput) (*iam.SimulatePrincipalPolicyOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.empty {
+		return &iam.SimulatePrincipalPolicyOutput{}, nil
+	}
+	return &iam.SimulatePrincipalPolicyOutput{
+		EvaluationResults: []*iam.EvaluationResult{
+			{EvalDecision: aws.String(f.decision)},
+		},
+	}, nil
+}
+
+func TestVerifyRoleCanPutLogEventsAllowed(t *testing.T) {
+	cli := &fakeIAMClient{decision: iam.PolicyEvaluationDecisionTypeAllowed}
+	if err := VerifyRoleCanPutLogEvents(cli, "arn:aws:iam::123456789012:role/test", "arn:aws:logs:us-west-2:123456789012:log-group:test"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestVerifyRoleCanPutLogEventsDenied(t *testing.T) {
+	cli := &fakeIAMClient{decision: iam.PolicyEvaluationDecisionTypeImplicitDeny}
+	if err := VerifyRoleCanPutLogEvents(cli, "arn:aws:iam::123456789012:role/test", "arn:aws:logs:us-west-2:123456789012:log-group:test"); err == nil {
+		t.Fatal("expected error for denied logs:PutLogEvents, got nil")
+	}
+}
+
+// TestVerifyRoleCanPutLogEventsNoEvaluationResults is a regression test
+// for a bug where an empty "EvaluationResults" (the "for" loop never
+// executing) fell through to a nil return, reporting "allowed" without
+// having verified anything.
+func TestVerifyRoleCanPutLogEventsNoEvaluationResults(t *testing.T) {
+	cli := &fakeIAMClient{empty: true}
+	if err := VerifyRoleCanPutLogEvents(cli, "arn:aws:iam::123456789012:role/test", "arn:aws:logs:us-west-2:123456789012:log-group:test"); err == nil {
+		t.Fatal("expected error for empty EvaluationResults, got nil")
+	}
+}
@@ -0,0 +1,80 @@
+// Package events implements a structured event/telemetry log for
+// cluster-lifecycle phase transitions (CFN stack create/delete, EKS
+// cluster create, MNG ready, add-on install start/end, test run,
+// teardown), so failures can be diagnosed from a single log instead of
+// scattered shell scripts and raw CFN events.
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Outcome is the terminal state of a recorded event.
+type Outcome string
+
+const (
+	// OutcomeStart marks the beginning of a phase.
+	OutcomeStart Outcome = "start"
+	// OutcomeSuccess marks a phase that completed successfully.
+	OutcomeSuccess Outcome = "success"
+	// OutcomeFailure marks a phase that failed.
+	OutcomeFailure Outcome = "failure"
+)
+
+// Event is a single structured record of a cluster-lifecycle phase transition.
+type Event struct {
+	Time        time.Time `json:"time"`
+	Phase       string    `json:"phase"`
+	Outcome     Outcome   `json:"outcome"`
+	ResourceIDs []string  `json:"resource-ids,omitempty"`
+	Message     string    `json:"message,omitempty"`
+}
+
+// Recorder appends structured events to a local JSON-lines file, and
+// optionally streams them to a CloudWatch Logs group.
+type Recorder struct {
+	logPath string
+	sink    Sink
+}
+
+// Sink streams recorded events to an external system (e.g. CloudWatch Logs).
+type Sink interface {
+	Put(Event) error
+}
+
+// New returns a Recorder that appends to "logPath", optionally also
+// writing through to "sink" (pass nil to disable remote streaming).
+func New(logPath string, sink Sink) *Recorder {
+	return &Recorder{logPath: logPath, sink: sink}
+}
+
+// Record appends "ev" to the local event log, and forwards it to the
+// configured sink if one is set. Local write errors are returned;
+// sink errors are returned wrapped so the caller can decide whether a
+// lost CloudWatch write should fail the run.
+func (r *Recorder) Record(ev Event) error {
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+
+	f, err := os.OpenFile(r.logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	if err := enc.Encode(ev); err != nil {
+		return err
+	}
+
+	if r.sink != nil {
+		if err := r.sink.Put(ev); err != nil {
+			return fmt.Errorf("failed to forward event %q to sink (%v)", ev.Phase, err)
+		}
+	}
+	return nil
+}